@@ -0,0 +1,30 @@
+package resource
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// UnmarshalParam decodes a request's generic payload (as delivered by the
+// websocket layer) into a typed param struct.
+func UnmarshalParam(data interface{}, param interface{}) error {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("marshal param: %v", err)
+	}
+	if err := json.Unmarshal(raw, param); err != nil {
+		return fmt.Errorf("unmarshal param: %v", err)
+	}
+	return nil
+}
+
+// ToUnstructured decodes raw JSON into an unstructured.Unstructured object.
+func ToUnstructured(raw []byte) (*unstructured.Unstructured, error) {
+	obj := &unstructured.Unstructured{}
+	if err := obj.UnmarshalJSON(raw); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
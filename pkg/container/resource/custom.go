@@ -0,0 +1,159 @@
+package resource
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/openspacee/ospagent/pkg/kubernetes"
+	"github.com/openspacee/ospagent/pkg/utils"
+	"github.com/openspacee/ospagent/pkg/websocket"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/cache"
+)
+
+// Custom serves arbitrary CustomResources (and any other GVR) discovered
+// from the cluster at runtime, so the frontend can browse and watch CRDs
+// such as Karmada's PropagationPolicy or ServiceExports without the agent
+// needing to be recompiled for every new CRD.
+type Custom struct {
+	kubeClient      *kubernetes.KubeClient
+	sendResponse    websocket.SendResponse
+	watch           *WatchResource
+	dynamicRegistry kubernetes.DynamicRegistry
+}
+
+func NewCustom(kubeClient *kubernetes.KubeClient, sendResponse websocket.SendResponse, watch *WatchResource, dynamicRegistry kubernetes.DynamicRegistry) *Custom {
+	return &Custom{
+		kubeClient:      kubeClient,
+		sendResponse:    sendResponse,
+		watch:           watch,
+		dynamicRegistry: dynamicRegistry,
+	}
+}
+
+// CustomParam identifies the GVR (and, for Get/Delete/Update, the object)
+// that a request operates on.
+type CustomParam struct {
+	Group     string                 `json:"group"`
+	Version   string                 `json:"version"`
+	Resource  string                 `json:"resource"`
+	Namespace string                 `json:"namespace"`
+	Name      string                 `json:"name"`
+	Selector  string                 `json:"selector"`
+	Object    map[string]interface{} `json:"object"`
+}
+
+func (c *Custom) gvr(param *CustomParam) (schema.GroupVersionResource, error) {
+	return c.dynamicRegistry.ResourceFor(param.Group, param.Version, param.Resource)
+}
+
+// ensureInformer resolves param's GVR and makes sure its informer has been
+// created (and started) at least once, so every verb - not just List -
+// causes the GVR to be cached for subsequent calls.
+func (c *Custom) ensureInformer(param *CustomParam) (schema.GroupVersionResource, cache.SharedIndexInformer, error) {
+	gvr, err := c.gvr(param)
+	if err != nil {
+		return schema.GroupVersionResource{}, nil, err
+	}
+	informer, err := c.dynamicRegistry.InformerFor(gvr)
+	if err != nil {
+		return schema.GroupVersionResource{}, nil, err
+	}
+	return gvr, informer, nil
+}
+
+func (c *Custom) List(data interface{}) *utils.Response {
+	param := new(CustomParam)
+	if err := UnmarshalParam(data, param); err != nil {
+		return utils.Error(err)
+	}
+	_, informer, err := c.ensureInformer(param)
+	if err != nil {
+		return utils.Error(err)
+	}
+
+	selector := labels.Everything()
+	if param.Selector != "" {
+		selector, err = labels.Parse(param.Selector)
+		if err != nil {
+			return utils.Error(err)
+		}
+	}
+
+	var objs []interface{}
+	if param.Namespace != "" {
+		objs, err = informer.GetIndexer().ByIndex("namespace", param.Namespace)
+		if err != nil {
+			return utils.Error(err)
+		}
+	} else {
+		objs = informer.GetIndexer().List()
+	}
+
+	items := make([]interface{}, 0, len(objs))
+	for _, obj := range objs {
+		u, ok := obj.(interface{ GetLabels() map[string]string })
+		if ok && !selector.Matches(labels.Set(u.GetLabels())) {
+			continue
+		}
+		items = append(items, obj)
+	}
+	return utils.Success(items)
+}
+
+func (c *Custom) Get(data interface{}) *utils.Response {
+	param := new(CustomParam)
+	if err := UnmarshalParam(data, param); err != nil {
+		return utils.Error(err)
+	}
+	gvr, _, err := c.ensureInformer(param)
+	if err != nil {
+		return utils.Error(err)
+	}
+	obj, err := c.kubeClient.DynamicClient.Resource(gvr).Namespace(param.Namespace).Get(context.TODO(), param.Name, metav1.GetOptions{})
+	if err != nil {
+		return utils.Error(err)
+	}
+	return utils.Success(obj)
+}
+
+func (c *Custom) Delete(data interface{}) *utils.Response {
+	param := new(CustomParam)
+	if err := UnmarshalParam(data, param); err != nil {
+		return utils.Error(err)
+	}
+	gvr, _, err := c.ensureInformer(param)
+	if err != nil {
+		return utils.Error(err)
+	}
+	if err := c.kubeClient.DynamicClient.Resource(gvr).Namespace(param.Namespace).Delete(context.TODO(), param.Name, metav1.DeleteOptions{}); err != nil {
+		return utils.Error(err)
+	}
+	return utils.Success(nil)
+}
+
+func (c *Custom) Update(data interface{}) *utils.Response {
+	param := new(CustomParam)
+	if err := UnmarshalParam(data, param); err != nil {
+		return utils.Error(err)
+	}
+	gvr, _, err := c.ensureInformer(param)
+	if err != nil {
+		return utils.Error(err)
+	}
+	raw, err := json.Marshal(param.Object)
+	if err != nil {
+		return utils.Error(err)
+	}
+	obj, err := ToUnstructured(raw)
+	if err != nil {
+		return utils.Error(err)
+	}
+	updated, err := c.kubeClient.DynamicClient.Resource(gvr).Namespace(param.Namespace).Update(context.TODO(), obj, metav1.UpdateOptions{})
+	if err != nil {
+		return utils.Error(err)
+	}
+	return utils.Success(updated)
+}
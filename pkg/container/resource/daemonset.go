@@ -0,0 +1,88 @@
+package resource
+
+import (
+	"context"
+
+	"github.com/openspacee/ospagent/pkg/kubernetes"
+	"github.com/openspacee/ospagent/pkg/utils"
+	"github.com/openspacee/ospagent/pkg/websocket"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+type DaemonSet struct {
+	kubeClient   *kubernetes.KubeClient
+	sendResponse websocket.SendResponse
+	watch        *WatchResource
+}
+
+func NewDaemonSet(kubeClient *kubernetes.KubeClient, sendResponse websocket.SendResponse, watch *WatchResource) *DaemonSet {
+	return &DaemonSet{
+		kubeClient:   kubeClient,
+		sendResponse: sendResponse,
+		watch:        watch,
+	}
+}
+
+type DaemonSetParam struct {
+	Namespace string            `json:"namespace"`
+	Name      string            `json:"name"`
+	Selector  string            `json:"selector"`
+	Object    *appsv1.DaemonSet `json:"object"`
+}
+
+func (d *DaemonSet) List(data interface{}) *utils.Response {
+	param := new(DaemonSetParam)
+	if err := UnmarshalParam(data, param); err != nil {
+		return utils.Error(err)
+	}
+	selector := labels.Everything()
+	var err error
+	if param.Selector != "" {
+		selector, err = labels.Parse(param.Selector)
+		if err != nil {
+			return utils.Error(err)
+		}
+	}
+	daemonSets, err := d.kubeClient.InformerRegistry.DaemonSetLister().DaemonSets(param.Namespace).List(selector)
+	if err != nil {
+		return utils.Error(err)
+	}
+	return utils.Success(daemonSets)
+}
+
+func (d *DaemonSet) Get(data interface{}) *utils.Response {
+	param := new(DaemonSetParam)
+	if err := UnmarshalParam(data, param); err != nil {
+		return utils.Error(err)
+	}
+	daemonSet, err := d.kubeClient.InformerRegistry.DaemonSetLister().DaemonSets(param.Namespace).Get(param.Name)
+	if err != nil {
+		return utils.Error(err)
+	}
+	return utils.Success(daemonSet)
+}
+
+func (d *DaemonSet) Delete(data interface{}) *utils.Response {
+	param := new(DaemonSetParam)
+	if err := UnmarshalParam(data, param); err != nil {
+		return utils.Error(err)
+	}
+	if err := d.kubeClient.ClientSet.AppsV1().DaemonSets(param.Namespace).Delete(context.TODO(), param.Name, metav1.DeleteOptions{}); err != nil {
+		return utils.Error(err)
+	}
+	return utils.Success(nil)
+}
+
+func (d *DaemonSet) Update(data interface{}) *utils.Response {
+	param := new(DaemonSetParam)
+	if err := UnmarshalParam(data, param); err != nil {
+		return utils.Error(err)
+	}
+	updated, err := d.kubeClient.ClientSet.AppsV1().DaemonSets(param.Namespace).Update(context.TODO(), param.Object, metav1.UpdateOptions{})
+	if err != nil {
+		return utils.Error(err)
+	}
+	return utils.Success(updated)
+}
@@ -0,0 +1,107 @@
+package resource
+
+import (
+	"context"
+
+	"github.com/openspacee/ospagent/pkg/kubernetes"
+	"github.com/openspacee/ospagent/pkg/utils"
+	"github.com/openspacee/ospagent/pkg/websocket"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+type Deployment struct {
+	kubeClient   *kubernetes.KubeClient
+	sendResponse websocket.SendResponse
+	watch        *WatchResource
+}
+
+func NewDeployment(kubeClient *kubernetes.KubeClient, sendResponse websocket.SendResponse, watch *WatchResource) *Deployment {
+	return &Deployment{
+		kubeClient:   kubeClient,
+		sendResponse: sendResponse,
+		watch:        watch,
+	}
+}
+
+type DeploymentParam struct {
+	Namespace string             `json:"namespace"`
+	Name      string             `json:"name"`
+	Selector  string             `json:"selector"`
+	Replicas  int32              `json:"replicas"`
+	Object    *appsv1.Deployment `json:"object"`
+}
+
+func (d *Deployment) List(data interface{}) *utils.Response {
+	param := new(DeploymentParam)
+	if err := UnmarshalParam(data, param); err != nil {
+		return utils.Error(err)
+	}
+	selector := labels.Everything()
+	var err error
+	if param.Selector != "" {
+		selector, err = labels.Parse(param.Selector)
+		if err != nil {
+			return utils.Error(err)
+		}
+	}
+	deployments, err := d.kubeClient.InformerRegistry.DeploymentLister().Deployments(param.Namespace).List(selector)
+	if err != nil {
+		return utils.Error(err)
+	}
+	return utils.Success(deployments)
+}
+
+func (d *Deployment) Get(data interface{}) *utils.Response {
+	param := new(DeploymentParam)
+	if err := UnmarshalParam(data, param); err != nil {
+		return utils.Error(err)
+	}
+	deployment, err := d.kubeClient.InformerRegistry.DeploymentLister().Deployments(param.Namespace).Get(param.Name)
+	if err != nil {
+		return utils.Error(err)
+	}
+	return utils.Success(deployment)
+}
+
+func (d *Deployment) Delete(data interface{}) *utils.Response {
+	param := new(DeploymentParam)
+	if err := UnmarshalParam(data, param); err != nil {
+		return utils.Error(err)
+	}
+	if err := d.kubeClient.ClientSet.AppsV1().Deployments(param.Namespace).Delete(context.TODO(), param.Name, metav1.DeleteOptions{}); err != nil {
+		return utils.Error(err)
+	}
+	return utils.Success(nil)
+}
+
+func (d *Deployment) Update(data interface{}) *utils.Response {
+	param := new(DeploymentParam)
+	if err := UnmarshalParam(data, param); err != nil {
+		return utils.Error(err)
+	}
+	updated, err := d.kubeClient.ClientSet.AppsV1().Deployments(param.Namespace).Update(context.TODO(), param.Object, metav1.UpdateOptions{})
+	if err != nil {
+		return utils.Error(err)
+	}
+	return utils.Success(updated)
+}
+
+func (d *Deployment) Scale(data interface{}) *utils.Response {
+	param := new(DeploymentParam)
+	if err := UnmarshalParam(data, param); err != nil {
+		return utils.Error(err)
+	}
+	client := d.kubeClient.ClientSet.AppsV1().Deployments(param.Namespace)
+	deployment, err := client.Get(context.TODO(), param.Name, metav1.GetOptions{})
+	if err != nil {
+		return utils.Error(err)
+	}
+	deployment.Spec.Replicas = &param.Replicas
+	updated, err := client.Update(context.TODO(), deployment, metav1.UpdateOptions{})
+	if err != nil {
+		return utils.Error(err)
+	}
+	return utils.Success(updated)
+}
@@ -0,0 +1,141 @@
+package resource
+
+import (
+	"context"
+
+	"github.com/openspacee/ospagent/pkg/kubernetes"
+	"github.com/openspacee/ospagent/pkg/utils"
+	"github.com/openspacee/ospagent/pkg/websocket"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+type Event struct {
+	kubeClient   *kubernetes.KubeClient
+	sendResponse websocket.SendResponse
+	watch        *WatchResource
+}
+
+func NewEvent(kubeClient *kubernetes.KubeClient, sendResponse websocket.SendResponse, watch *WatchResource) *Event {
+	return &Event{
+		kubeClient:   kubeClient,
+		sendResponse: sendResponse,
+		watch:        watch,
+	}
+}
+
+type EventParam struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	// InvolvedObjectNamespace/Kind/Name/UID filter the listed events to
+	// those concerning a single object, e.g. for a per-resource event
+	// stream. InvolvedObjectUID, when set, is served directly off the
+	// IndexEventByInvolvedObjectUID index instead of scanning every event
+	// in the namespace.
+	InvolvedObjectNamespace string        `json:"involvedObjectNamespace"`
+	InvolvedObjectKind      string        `json:"involvedObjectKind"`
+	InvolvedObjectName      string        `json:"involvedObjectName"`
+	InvolvedObjectUID       string        `json:"involvedObjectUID"`
+	Object                  *corev1.Event `json:"object"`
+}
+
+func (e *Event) List(data interface{}) *utils.Response {
+	param := new(EventParam)
+	if err := UnmarshalParam(data, param); err != nil {
+		return utils.Error(err)
+	}
+
+	var events []*corev1.Event
+	if param.InvolvedObjectUID != "" {
+		var err error
+		events, err = e.eventsByInvolvedObjectUID(param.InvolvedObjectUID)
+		if err != nil {
+			return utils.Error(err)
+		}
+	} else {
+		var err error
+		events, err = e.kubeClient.InformerRegistry.EventLister().Events(param.Namespace).List(labels.Everything())
+		if err != nil {
+			return utils.Error(err)
+		}
+	}
+
+	if param.Namespace == "" && param.InvolvedObjectName == "" && param.InvolvedObjectKind == "" && param.InvolvedObjectNamespace == "" {
+		return utils.Success(events)
+	}
+
+	filtered := make([]*corev1.Event, 0, len(events))
+	for _, event := range events {
+		if param.Namespace != "" && event.Namespace != param.Namespace {
+			continue
+		}
+		involved := event.InvolvedObject
+		if param.InvolvedObjectKind != "" && involved.Kind != param.InvolvedObjectKind {
+			continue
+		}
+		if param.InvolvedObjectName != "" && involved.Name != param.InvolvedObjectName {
+			continue
+		}
+		if param.InvolvedObjectNamespace != "" && involved.Namespace != param.InvolvedObjectNamespace {
+			continue
+		}
+		filtered = append(filtered, event)
+	}
+	return utils.Success(filtered)
+}
+
+// eventsByInvolvedObjectUID looks events up in O(k) via the informer's
+// IndexEventByInvolvedObjectUID index instead of listing every event and
+// scanning InvolvedObject by hand.
+func (e *Event) eventsByInvolvedObjectUID(uid string) ([]*corev1.Event, error) {
+	indexer := e.kubeClient.InformerRegistry.EventInformer().Informer().GetIndexer()
+	objs, err := indexer.ByIndex(kubernetes.IndexEventByInvolvedObjectUID, uid)
+	if err != nil {
+		return nil, err
+	}
+	events := make([]*corev1.Event, 0, len(objs))
+	for _, obj := range objs {
+		event, ok := obj.(*corev1.Event)
+		if !ok {
+			continue
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+func (e *Event) Get(data interface{}) *utils.Response {
+	param := new(EventParam)
+	if err := UnmarshalParam(data, param); err != nil {
+		return utils.Error(err)
+	}
+	event, err := e.kubeClient.InformerRegistry.EventLister().Events(param.Namespace).Get(param.Name)
+	if err != nil {
+		return utils.Error(err)
+	}
+	return utils.Success(event)
+}
+
+func (e *Event) Delete(data interface{}) *utils.Response {
+	param := new(EventParam)
+	if err := UnmarshalParam(data, param); err != nil {
+		return utils.Error(err)
+	}
+	if err := e.kubeClient.ClientSet.CoreV1().Events(param.Namespace).Delete(context.TODO(), param.Name, metav1.DeleteOptions{}); err != nil {
+		return utils.Error(err)
+	}
+	return utils.Success(nil)
+}
+
+func (e *Event) Update(data interface{}) *utils.Response {
+	param := new(EventParam)
+	if err := UnmarshalParam(data, param); err != nil {
+		return utils.Error(err)
+	}
+	updated, err := e.kubeClient.ClientSet.CoreV1().Events(param.Namespace).Update(context.TODO(), param.Object, metav1.UpdateOptions{})
+	if err != nil {
+		return utils.Error(err)
+	}
+	return utils.Success(updated)
+}
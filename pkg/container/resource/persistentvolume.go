@@ -0,0 +1,87 @@
+package resource
+
+import (
+	"context"
+
+	"github.com/openspacee/ospagent/pkg/kubernetes"
+	"github.com/openspacee/ospagent/pkg/utils"
+	"github.com/openspacee/ospagent/pkg/websocket"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+type PersistentVolume struct {
+	kubeClient   *kubernetes.KubeClient
+	sendResponse websocket.SendResponse
+	watch        *WatchResource
+}
+
+func NewPersistentVolume(kubeClient *kubernetes.KubeClient, sendResponse websocket.SendResponse, watch *WatchResource) *PersistentVolume {
+	return &PersistentVolume{
+		kubeClient:   kubeClient,
+		sendResponse: sendResponse,
+		watch:        watch,
+	}
+}
+
+type PersistentVolumeParam struct {
+	Name     string                   `json:"name"`
+	Selector string                   `json:"selector"`
+	Object   *corev1.PersistentVolume `json:"object"`
+}
+
+func (p *PersistentVolume) List(data interface{}) *utils.Response {
+	param := new(PersistentVolumeParam)
+	if err := UnmarshalParam(data, param); err != nil {
+		return utils.Error(err)
+	}
+	selector := labels.Everything()
+	var err error
+	if param.Selector != "" {
+		selector, err = labels.Parse(param.Selector)
+		if err != nil {
+			return utils.Error(err)
+		}
+	}
+	pvs, err := p.kubeClient.InformerRegistry.PersistentVolumeLister().List(selector)
+	if err != nil {
+		return utils.Error(err)
+	}
+	return utils.Success(pvs)
+}
+
+func (p *PersistentVolume) Get(data interface{}) *utils.Response {
+	param := new(PersistentVolumeParam)
+	if err := UnmarshalParam(data, param); err != nil {
+		return utils.Error(err)
+	}
+	pv, err := p.kubeClient.InformerRegistry.PersistentVolumeLister().Get(param.Name)
+	if err != nil {
+		return utils.Error(err)
+	}
+	return utils.Success(pv)
+}
+
+func (p *PersistentVolume) Delete(data interface{}) *utils.Response {
+	param := new(PersistentVolumeParam)
+	if err := UnmarshalParam(data, param); err != nil {
+		return utils.Error(err)
+	}
+	if err := p.kubeClient.ClientSet.CoreV1().PersistentVolumes().Delete(context.TODO(), param.Name, metav1.DeleteOptions{}); err != nil {
+		return utils.Error(err)
+	}
+	return utils.Success(nil)
+}
+
+func (p *PersistentVolume) Update(data interface{}) *utils.Response {
+	param := new(PersistentVolumeParam)
+	if err := UnmarshalParam(data, param); err != nil {
+		return utils.Error(err)
+	}
+	updated, err := p.kubeClient.ClientSet.CoreV1().PersistentVolumes().Update(context.TODO(), param.Object, metav1.UpdateOptions{})
+	if err != nil {
+		return utils.Error(err)
+	}
+	return utils.Success(updated)
+}
@@ -0,0 +1,107 @@
+package resource
+
+import (
+	"context"
+
+	"github.com/openspacee/ospagent/pkg/kubernetes"
+	"github.com/openspacee/ospagent/pkg/utils"
+	"github.com/openspacee/ospagent/pkg/websocket"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+type StatefulSet struct {
+	kubeClient   *kubernetes.KubeClient
+	sendResponse websocket.SendResponse
+	watch        *WatchResource
+}
+
+func NewStatefulSet(kubeClient *kubernetes.KubeClient, sendResponse websocket.SendResponse, watch *WatchResource) *StatefulSet {
+	return &StatefulSet{
+		kubeClient:   kubeClient,
+		sendResponse: sendResponse,
+		watch:        watch,
+	}
+}
+
+type StatefulSetParam struct {
+	Namespace string              `json:"namespace"`
+	Name      string              `json:"name"`
+	Selector  string              `json:"selector"`
+	Replicas  int32               `json:"replicas"`
+	Object    *appsv1.StatefulSet `json:"object"`
+}
+
+func (s *StatefulSet) List(data interface{}) *utils.Response {
+	param := new(StatefulSetParam)
+	if err := UnmarshalParam(data, param); err != nil {
+		return utils.Error(err)
+	}
+	selector := labels.Everything()
+	var err error
+	if param.Selector != "" {
+		selector, err = labels.Parse(param.Selector)
+		if err != nil {
+			return utils.Error(err)
+		}
+	}
+	statefulSets, err := s.kubeClient.InformerRegistry.StatefulSetLister().StatefulSets(param.Namespace).List(selector)
+	if err != nil {
+		return utils.Error(err)
+	}
+	return utils.Success(statefulSets)
+}
+
+func (s *StatefulSet) Get(data interface{}) *utils.Response {
+	param := new(StatefulSetParam)
+	if err := UnmarshalParam(data, param); err != nil {
+		return utils.Error(err)
+	}
+	statefulSet, err := s.kubeClient.InformerRegistry.StatefulSetLister().StatefulSets(param.Namespace).Get(param.Name)
+	if err != nil {
+		return utils.Error(err)
+	}
+	return utils.Success(statefulSet)
+}
+
+func (s *StatefulSet) Delete(data interface{}) *utils.Response {
+	param := new(StatefulSetParam)
+	if err := UnmarshalParam(data, param); err != nil {
+		return utils.Error(err)
+	}
+	if err := s.kubeClient.ClientSet.AppsV1().StatefulSets(param.Namespace).Delete(context.TODO(), param.Name, metav1.DeleteOptions{}); err != nil {
+		return utils.Error(err)
+	}
+	return utils.Success(nil)
+}
+
+func (s *StatefulSet) Update(data interface{}) *utils.Response {
+	param := new(StatefulSetParam)
+	if err := UnmarshalParam(data, param); err != nil {
+		return utils.Error(err)
+	}
+	updated, err := s.kubeClient.ClientSet.AppsV1().StatefulSets(param.Namespace).Update(context.TODO(), param.Object, metav1.UpdateOptions{})
+	if err != nil {
+		return utils.Error(err)
+	}
+	return utils.Success(updated)
+}
+
+func (s *StatefulSet) Scale(data interface{}) *utils.Response {
+	param := new(StatefulSetParam)
+	if err := UnmarshalParam(data, param); err != nil {
+		return utils.Error(err)
+	}
+	client := s.kubeClient.ClientSet.AppsV1().StatefulSets(param.Namespace)
+	statefulSet, err := client.Get(context.TODO(), param.Name, metav1.GetOptions{})
+	if err != nil {
+		return utils.Error(err)
+	}
+	statefulSet.Spec.Replicas = &param.Replicas
+	updated, err := client.Update(context.TODO(), statefulSet, metav1.UpdateOptions{})
+	if err != nil {
+		return utils.Error(err)
+	}
+	return utils.Success(updated)
+}
@@ -0,0 +1,275 @@
+package resource
+
+import (
+	"sync"
+	"time"
+
+	"github.com/openspacee/ospagent/pkg/utils"
+	"github.com/openspacee/ospagent/pkg/websocket"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+)
+
+// watchEvent is the frame pushed to the client for every informer delta.
+type watchEvent struct {
+	Resource        string      `json:"resource"`
+	Action          string      `json:"action"`
+	Object          interface{} `json:"object"`
+	ResourceVersion string      `json:"resourceVersion"`
+}
+
+const (
+	watchAdded    = "added"
+	watchModified = "modified"
+	watchDeleted  = "deleted"
+)
+
+// subscription describes one client's interest in a resource kind, scoped
+// to an optional namespace and label selector.
+type subscription struct {
+	id        string
+	namespace string
+	selector  labels.Selector
+}
+
+// WatchResource answers the GET-style watch request (WatchAction) and
+// provides a push-based SUBSCRIBE/UNSUBSCRIBE model on top of the shared
+// informers: each resource kind registers its ResourceEventHandler at most
+// once, deltas for the same object are coalesced through a workqueue, and
+// matching subscribers are notified over sendResponse.
+type WatchResource struct {
+	sendResponse websocket.SendResponse
+
+	mu            sync.Mutex
+	registered    map[string]cache.SharedIndexInformer
+	handlerRegs   map[string]cache.ResourceEventHandlerRegistration
+	subscriptions map[string]map[string]*subscription
+	pending       map[string]*watchQueueItem
+
+	queue workqueue.RateLimitingInterface
+}
+
+// watchQueueItem is the latest known delta for one resource/key. pending
+// holds these keyed by resource+"/"+key, and the queue itself only ever
+// carries that string key, so bursts of updates to the same object coalesce
+// into whatever is latest in pending by the time runWorker gets to it,
+// instead of queuing one entry per delta.
+type watchQueueItem struct {
+	resource string
+	action   string
+	key      string
+	obj      interface{}
+}
+
+func NewWatchResource(sendResponse websocket.SendResponse) *WatchResource {
+	w := &WatchResource{
+		sendResponse:  sendResponse,
+		registered:    make(map[string]cache.SharedIndexInformer),
+		handlerRegs:   make(map[string]cache.ResourceEventHandlerRegistration),
+		subscriptions: make(map[string]map[string]*subscription),
+		pending:       make(map[string]*watchQueueItem),
+		queue:         workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
+	}
+	go w.runWorker()
+	return w
+}
+
+// Close tears down everything this WatchResource owns when its websocket
+// connection goes away: it stops runWorker by shutting down the queue and
+// deregisters every ResourceEventHandler this connection added, so a
+// reconnecting client doesn't leave its old handler firing into the void on
+// the shared, long-lived informers.
+func (w *WatchResource) Close() {
+	w.queue.ShutDown()
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for resource, reg := range w.handlerRegs {
+		informer := w.registered[resource]
+		if informer == nil {
+			continue
+		}
+		if err := informer.RemoveEventHandler(reg); err != nil {
+			runtime.HandleError(err)
+		}
+	}
+}
+
+// WatchAction answers the single-shot GET-style watch request: it decodes
+// the request's WatchParam and replies with an empty success. It starts no
+// watch and sends no events; SUBSCRIBE does that.
+func (w *WatchResource) WatchAction(data interface{}) *utils.Response {
+	param := new(WatchParam)
+	if err := UnmarshalParam(data, param); err != nil {
+		return utils.Error(err)
+	}
+	return utils.Success(nil)
+}
+
+type WatchParam struct {
+	Resource  string `json:"resource"`
+	Namespace string `json:"namespace"`
+	Selector  string `json:"selector"`
+	ID        string `json:"id"`
+}
+
+// Subscribe registers (if needed) the ResourceEventHandlerFuncs for informer
+// and records that subscription id wants events for resource/namespace/selector.
+func (w *WatchResource) Subscribe(resource string, informer cache.SharedIndexInformer, data interface{}) *utils.Response {
+	param := new(WatchParam)
+	if err := UnmarshalParam(data, param); err != nil {
+		return utils.Error(err)
+	}
+	selector := labels.Everything()
+	if param.Selector != "" {
+		var err error
+		selector, err = labels.Parse(param.Selector)
+		if err != nil {
+			return utils.Error(err)
+		}
+	}
+
+	w.mu.Lock()
+	if _, ok := w.subscriptions[resource]; !ok {
+		w.subscriptions[resource] = make(map[string]*subscription)
+	}
+	w.subscriptions[resource][param.ID] = &subscription{
+		id:        param.ID,
+		namespace: param.Namespace,
+		selector:  selector,
+	}
+	_, alreadyRegistered := w.registered[resource]
+	needsHandler := !alreadyRegistered
+	if needsHandler {
+		// Claim the resource while still holding the lock so a concurrent
+		// Subscribe for the same resource does not register a second handler.
+		w.registered[resource] = informer
+	}
+	w.mu.Unlock()
+
+	if needsHandler {
+		w.registerHandler(resource, informer)
+	}
+	return utils.Success(nil)
+}
+
+// Unsubscribe removes a previously registered SUBSCRIBE by id.
+func (w *WatchResource) Unsubscribe(resource string, data interface{}) *utils.Response {
+	param := new(WatchParam)
+	if err := UnmarshalParam(data, param); err != nil {
+		return utils.Error(err)
+	}
+	w.mu.Lock()
+	delete(w.subscriptions[resource], param.ID)
+	w.mu.Unlock()
+	return utils.Success(nil)
+}
+
+func (w *WatchResource) registerHandler(resource string, informer cache.SharedIndexInformer) {
+	defer runtime.HandleCrash()
+	reg, err := informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			w.enqueue(resource, watchAdded, obj)
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			w.enqueue(resource, watchModified, newObj)
+		},
+		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			w.enqueue(resource, watchDeleted, obj)
+		},
+	})
+	if err != nil {
+		runtime.HandleError(err)
+		w.mu.Lock()
+		delete(w.registered, resource)
+		w.mu.Unlock()
+		return
+	}
+
+	w.mu.Lock()
+	w.handlerRegs[resource] = reg
+	w.mu.Unlock()
+}
+
+func (w *WatchResource) enqueue(resource, action string, obj interface{}) {
+	key, err := cache.MetaNamespaceKeyFunc(obj)
+	if err != nil {
+		runtime.HandleError(err)
+		return
+	}
+	qkey := resource + "/" + key
+
+	w.mu.Lock()
+	w.pending[qkey] = &watchQueueItem{resource: resource, action: action, key: key, obj: obj}
+	w.mu.Unlock()
+
+	// The queue only ever carries qkey, so workqueue.Type's own dedup
+	// collapses same-key bursts: if qkey is already queued, this Add is a
+	// no-op; if it is being processed, the queue re-adds it once Done is
+	// called. Either way the worker picks up whatever is latest in pending
+	// once it gets to it.
+	w.queue.Add(qkey)
+}
+
+// runWorker takes one coalesced key at a time off the queue, pausing 1ms
+// between keys, and dispatches its latest delta to the matching
+// subscribers. Keys are added with Add, not AddRateLimited, so the queue's
+// rate limiter does not delay them.
+func (w *WatchResource) runWorker() {
+	for {
+		qkey, shutdown := w.queue.Get()
+		if shutdown {
+			return
+		}
+
+		w.mu.Lock()
+		item, ok := w.pending[qkey.(string)]
+		delete(w.pending, qkey.(string))
+		w.mu.Unlock()
+
+		if ok {
+			w.process(item)
+		}
+		w.queue.Forget(qkey)
+		w.queue.Done(qkey)
+		// Small pacing delay so a resync burst on one resource kind
+		// can't starve the connection with thousands of frames at once.
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func (w *WatchResource) process(item *watchQueueItem) {
+	accessor, err := meta.Accessor(item.obj)
+	if err != nil {
+		runtime.HandleError(err)
+		return
+	}
+
+	w.mu.Lock()
+	subs := make([]*subscription, 0, len(w.subscriptions[item.resource]))
+	for _, sub := range w.subscriptions[item.resource] {
+		subs = append(subs, sub)
+	}
+	w.mu.Unlock()
+
+	for _, sub := range subs {
+		if sub.namespace != "" && sub.namespace != accessor.GetNamespace() {
+			continue
+		}
+		if !sub.selector.Matches(labels.Set(accessor.GetLabels())) {
+			continue
+		}
+		w.sendResponse(utils.Success(&watchEvent{
+			Resource:        item.resource,
+			Action:          item.action,
+			Object:          item.obj,
+			ResourceVersion: accessor.GetResourceVersion(),
+		}))
+	}
+}
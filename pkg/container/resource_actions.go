@@ -1,10 +1,13 @@
 package container
 
 import (
+	"io"
+
 	"github.com/openspacee/ospagent/pkg/container/resource"
 	"github.com/openspacee/ospagent/pkg/kubernetes"
 	"github.com/openspacee/ospagent/pkg/utils"
 	"github.com/openspacee/ospagent/pkg/websocket"
+	"k8s.io/client-go/tools/cache"
 )
 
 const (
@@ -16,6 +19,10 @@ const (
 	STDIN    = "stdin"
 	OPENLOG  = "openLog"
 	CLOSELOG = "closeLog"
+	SCALE    = "scale"
+
+	SUBSCRIBE   = "subscribe"
+	UNSUBSCRIBE = "unsubscribe"
 )
 
 type Handler func(interface{}) *utils.Response
@@ -25,6 +32,8 @@ type ActionHandler map[string]Handler
 type ResourceActions struct {
 	KubeClient            *kubernetes.KubeClient
 	ResourceActionHandler map[string]ActionHandler
+
+	watch *resource.WatchResource
 }
 
 func NewResourceActions(kubeClient *kubernetes.KubeClient, sendResponse websocket.SendResponse) *ResourceActions {
@@ -36,43 +45,145 @@ func NewResourceActions(kubeClient *kubernetes.KubeClient, sendResponse websocke
 	}
 	actionHandlers["watch"] = watchActions
 
+	// subscribeFor/unsubscribeFor bind a resource kind and its informer to
+	// the shared WatchResource so every resource's action table can expose
+	// SUBSCRIBE/UNSUBSCRIBE without duplicating the closure below.
+	subscribeFor := func(name string, informer cache.SharedIndexInformer) Handler {
+		return func(data interface{}) *utils.Response {
+			return watch.Subscribe(name, informer, data)
+		}
+	}
+	unsubscribeFor := func(name string) Handler {
+		return func(data interface{}) *utils.Response {
+			return watch.Unsubscribe(name, data)
+		}
+	}
+
 	pod := resource.NewPod(kubeClient, sendResponse, watch)
 	podActions := ActionHandler{
-		LIST:     pod.List,
-		GET:      pod.Get,
-		EXEC:     pod.Exec,
-		STDIN:    pod.ExecStdIn,
-		OPENLOG:  pod.OpenLog,
-		CLOSELOG: pod.CloseLog,
-		DELETE:   pod.Delete,
-		UPDATE:   pod.Update,
+		LIST:        pod.List,
+		GET:         pod.Get,
+		EXEC:        pod.Exec,
+		STDIN:       pod.ExecStdIn,
+		OPENLOG:     pod.OpenLog,
+		CLOSELOG:    pod.CloseLog,
+		DELETE:      pod.Delete,
+		UPDATE:      pod.Update,
+		SUBSCRIBE:   subscribeFor("pod", kubeClient.InformerRegistry.PodInformer().Informer()),
+		UNSUBSCRIBE: unsubscribeFor("pod"),
 	}
 	actionHandlers["pod"] = podActions
 
 	ns := resource.NewNamespace(kubeClient, sendResponse, watch)
 	nsActions := ActionHandler{
-		LIST: ns.List,
+		LIST:        ns.List,
+		SUBSCRIBE:   subscribeFor("namespace", kubeClient.InformerRegistry.NamespaceInformer().Informer()),
+		UNSUBSCRIBE: unsubscribeFor("namespace"),
 	}
 	actionHandlers["namespace"] = nsActions
 
 	node := resource.NewNode(kubeClient, sendResponse)
 	nodeActions := ActionHandler{
-		LIST: node.List,
+		LIST:        node.List,
+		SUBSCRIBE:   subscribeFor("node", kubeClient.InformerRegistry.NodeInformer().Informer()),
+		UNSUBSCRIBE: unsubscribeFor("node"),
 	}
 	actionHandlers["node"] = nodeActions
 
 	configMap := resource.NewConfigMap(kubeClient, sendResponse)
 	configMapActions := ActionHandler{
-		LIST: configMap.List,
+		LIST:        configMap.List,
+		SUBSCRIBE:   subscribeFor("configMap", kubeClient.InformerRegistry.ConfigMapInformer().Informer()),
+		UNSUBSCRIBE: unsubscribeFor("configMap"),
 	}
 	actionHandlers["configMap"] = configMapActions
 
+	deployment := resource.NewDeployment(kubeClient, sendResponse, watch)
+	deploymentActions := ActionHandler{
+		LIST:        deployment.List,
+		GET:         deployment.Get,
+		DELETE:      deployment.Delete,
+		UPDATE:      deployment.Update,
+		SCALE:       deployment.Scale,
+		SUBSCRIBE:   subscribeFor("deployment", kubeClient.InformerRegistry.DeploymentInformer().Informer()),
+		UNSUBSCRIBE: unsubscribeFor("deployment"),
+	}
+	actionHandlers["deployment"] = deploymentActions
+
+	statefulSet := resource.NewStatefulSet(kubeClient, sendResponse, watch)
+	statefulSetActions := ActionHandler{
+		LIST:        statefulSet.List,
+		GET:         statefulSet.Get,
+		DELETE:      statefulSet.Delete,
+		UPDATE:      statefulSet.Update,
+		SCALE:       statefulSet.Scale,
+		SUBSCRIBE:   subscribeFor("statefulSet", kubeClient.InformerRegistry.StatefulSetInformer().Informer()),
+		UNSUBSCRIBE: unsubscribeFor("statefulSet"),
+	}
+	actionHandlers["statefulSet"] = statefulSetActions
+
+	daemonSet := resource.NewDaemonSet(kubeClient, sendResponse, watch)
+	daemonSetActions := ActionHandler{
+		LIST:        daemonSet.List,
+		GET:         daemonSet.Get,
+		DELETE:      daemonSet.Delete,
+		UPDATE:      daemonSet.Update,
+		SUBSCRIBE:   subscribeFor("daemonSet", kubeClient.InformerRegistry.DaemonSetInformer().Informer()),
+		UNSUBSCRIBE: unsubscribeFor("daemonSet"),
+	}
+	actionHandlers["daemonSet"] = daemonSetActions
+
+	event := resource.NewEvent(kubeClient, sendResponse, watch)
+	eventActions := ActionHandler{
+		LIST:        event.List,
+		GET:         event.Get,
+		DELETE:      event.Delete,
+		UPDATE:      event.Update,
+		SUBSCRIBE:   subscribeFor("event", kubeClient.InformerRegistry.EventInformer().Informer()),
+		UNSUBSCRIBE: unsubscribeFor("event"),
+	}
+	actionHandlers["event"] = eventActions
+
+	persistentVolume := resource.NewPersistentVolume(kubeClient, sendResponse, watch)
+	persistentVolumeActions := ActionHandler{
+		LIST:        persistentVolume.List,
+		GET:         persistentVolume.Get,
+		DELETE:      persistentVolume.Delete,
+		UPDATE:      persistentVolume.Update,
+		SUBSCRIBE:   subscribeFor("persistentVolume", kubeClient.InformerRegistry.PersistentVolumeInformer().Informer()),
+		UNSUBSCRIBE: unsubscribeFor("persistentVolume"),
+	}
+	actionHandlers["persistentVolume"] = persistentVolumeActions
+
+	custom := resource.NewCustom(kubeClient, sendResponse, watch, kubeClient.DynamicRegistry)
+	customActions := ActionHandler{
+		LIST:   custom.List,
+		GET:    custom.Get,
+		DELETE: custom.Delete,
+		UPDATE: custom.Update,
+	}
+	actionHandlers["custom"] = customActions
+
 	return &ResourceActions{
 		KubeClient:            kubeClient,
 		ResourceActionHandler: actionHandlers,
+		watch:                 watch,
 	}
 }
 
 func (r *ResourceActions) GetRequestHandler(resource string, action string) Handler {
 	return r.ResourceActionHandler[resource][action]
 }
+
+var _ io.Closer = (*ResourceActions)(nil)
+
+// Close tears down everything this ResourceActions owns for its websocket
+// connection: it implements io.Closer so the code that owns the connection
+// lifecycle (the websocket layer, outside this package) can close it the
+// same way it closes any other per-connection resource. It MUST be called
+// when that connection closes, or the SUBSCRIBE handlers and workqueue
+// goroutine started for it leak for the life of the agent process.
+func (r *ResourceActions) Close() error {
+	r.watch.Close()
+	return nil
+}
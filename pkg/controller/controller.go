@@ -0,0 +1,110 @@
+package controller
+
+import (
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+)
+
+// SyncFunc reconciles the object identified by key (a namespace/name, as
+// produced by cache.MetaNamespaceKeyFunc) against the desired state.
+// Returning an error requeues the key with exponential backoff, up to 5
+// times; after that the key is dropped from the queue.
+type SyncFunc func(key string) error
+
+// Controller drives a SyncFunc off a SharedIndexInformer's event stream,
+// modeled on the standard client-go sample-controller pattern.
+type Controller interface {
+	Run(workers int, stopCh <-chan struct{})
+}
+
+type controller struct {
+	name        string
+	informer    cache.SharedIndexInformer
+	queue       workqueue.RateLimitingInterface
+	syncHandler SyncFunc
+}
+
+// New wires informer's AddFunc/UpdateFunc/DeleteFunc to enqueue
+// namespace/name keys and returns a Controller that processes them with
+// syncHandler once started via Run.
+func New(name string, informer cache.SharedIndexInformer, syncHandler SyncFunc) Controller {
+	c := &controller{
+		name:        name,
+		informer:    informer,
+		queue:       workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
+		syncHandler: syncHandler,
+	}
+
+	informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: c.enqueue,
+		UpdateFunc: func(old, new interface{}) {
+			c.enqueue(new)
+		},
+		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			c.enqueue(obj)
+		},
+	})
+
+	return c
+}
+
+func (c *controller) enqueue(obj interface{}) {
+	key, err := cache.MetaNamespaceKeyFunc(obj)
+	if err != nil {
+		runtime.HandleError(err)
+		return
+	}
+	c.queue.Add(key)
+}
+
+func (c *controller) Run(workers int, stopCh <-chan struct{}) {
+	defer runtime.HandleCrash()
+	defer c.queue.ShutDown()
+
+	klog.Infof("starting %s controller", c.name)
+	if !cache.WaitForCacheSync(stopCh, c.informer.HasSynced) {
+		runtime.HandleError(fmt.Errorf("%s controller: timed out waiting for caches to sync", c.name))
+		return
+	}
+
+	for i := 0; i < workers; i++ {
+		go wait.Until(c.runWorker, time.Second, stopCh)
+	}
+
+	<-stopCh
+	klog.Infof("stopping %s controller", c.name)
+}
+
+func (c *controller) runWorker() {
+	for c.processNextItem() {
+	}
+}
+
+func (c *controller) processNextItem() bool {
+	key, shutdown := c.queue.Get()
+	if shutdown {
+		return false
+	}
+	defer c.queue.Done(key)
+
+	if err := c.syncHandler(key.(string)); err != nil {
+		if c.queue.NumRequeues(key) < 5 {
+			runtime.HandleError(fmt.Errorf("%s controller: error syncing %q, retrying: %v", c.name, key, err))
+			c.queue.AddRateLimited(key)
+			return true
+		}
+		runtime.HandleError(fmt.Errorf("%s controller: dropping %q out of the queue: %v", c.name, key, err))
+	}
+
+	c.queue.Forget(key)
+	return true
+}
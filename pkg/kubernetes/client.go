@@ -0,0 +1,53 @@
+package kubernetes
+
+import (
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// KubeClient bundles every client/registry a resource handler needs for a
+// single cluster connection: the typed clientset for mutations, the shared
+// InformerRegistry for cached reads, and the dynamic client/registry pair
+// for CRDs that have no generated clientset.
+type KubeClient struct {
+	Config          *rest.Config
+	ClientSet       kubernetes.Interface
+	DynamicClient   dynamic.Interface
+	DiscoveryClient discovery.DiscoveryInterface
+
+	InformerRegistry InformerRegistry
+	DynamicRegistry  DynamicRegistry
+}
+
+// NewKubeClient builds the clientset/dynamic/discovery clients for config
+// and wires them into the shared InformerRegistry and DynamicRegistry.
+func NewKubeClient(config *rest.Config, opts InformerOptions, stopCh <-chan struct{}) (*KubeClient, error) {
+	clientSet, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	dynamicClient, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	informerRegistry, err := NewInformerRegistry(clientSet, opts, stopCh)
+	if err != nil {
+		return nil, err
+	}
+
+	return &KubeClient{
+		Config:           config,
+		ClientSet:        clientSet,
+		DynamicClient:    dynamicClient,
+		DiscoveryClient:  discoveryClient,
+		InformerRegistry: informerRegistry,
+		DynamicRegistry:  NewDynamicRegistry(dynamicClient, discoveryClient, opts, stopCh),
+	}, nil
+}
@@ -0,0 +1,97 @@
+package kubernetes
+
+import (
+	"fmt"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/restmapper"
+	"k8s.io/client-go/tools/cache"
+)
+
+// DynamicRegistry lazily builds and caches SharedIndexInformers for
+// arbitrary GroupVersionResources, including CRDs that are unknown at
+// compile time (e.g. Karmada's PropagationPolicy, ServiceExports).
+type DynamicRegistry interface {
+	// InformerFor returns the SharedIndexInformer for gvr, creating and
+	// starting it (and waiting for its cache to sync) on first use.
+	InformerFor(gvr schema.GroupVersionResource) (cache.SharedIndexInformer, error)
+	// ResourceFor resolves a group/version/resource triple to a
+	// GroupVersionResource via the RESTMapper, accepting either the
+	// resource plural or the kind.
+	ResourceFor(group, version, resource string) (schema.GroupVersionResource, error)
+}
+
+type DynamicRegistryImpl struct {
+	dynamicClient dynamic.Interface
+	restMapper    meta.RESTMapper
+	factory       dynamicinformer.DynamicSharedInformerFactory
+	stopCh        <-chan struct{}
+
+	mu        sync.Mutex
+	informers map[schema.GroupVersionResource]cache.SharedIndexInformer
+}
+
+// NewDynamicRegistry builds a registry of lazily-started dynamic informers,
+// scoped by opts the same way NewInformerRegistry scopes the typed
+// informers, so a namespace/label-restricted agent doesn't fall back to
+// watching CRDs cluster-wide.
+func NewDynamicRegistry(dynamicClient dynamic.Interface, discoveryClient discovery.DiscoveryInterface, opts InformerOptions, stopCh <-chan struct{}) DynamicRegistry {
+	mapper := restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(discoveryClient))
+	return &DynamicRegistryImpl{
+		dynamicClient: dynamicClient,
+		restMapper:    mapper,
+		factory: dynamicinformer.NewFilteredDynamicSharedInformerFactory(
+			dynamicClient,
+			opts.ResyncPeriod,
+			opts.Namespace,
+			opts.TweakListOptions,
+		),
+		stopCh:    stopCh,
+		informers: make(map[schema.GroupVersionResource]cache.SharedIndexInformer),
+	}
+}
+
+func (r *DynamicRegistryImpl) ResourceFor(group, version, resource string) (schema.GroupVersionResource, error) {
+	gvr := schema.GroupVersionResource{Group: group, Version: version, Resource: resource}
+	// Let the RESTMapper normalise resource (plural, singular or a
+	// lowercased kind) to its plural resource name, and fall back to a Kind
+	// lookup if that fails.
+	if resolved, err := r.restMapper.ResourceFor(gvr); err == nil {
+		return resolved, nil
+	}
+	gvk := schema.GroupVersionKind{Group: group, Version: version, Kind: resource}
+	mapping, err := r.restMapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return schema.GroupVersionResource{}, fmt.Errorf("unable to resolve resource %s/%s, %s: %v", group, version, resource, err)
+	}
+	return mapping.Resource, nil
+}
+
+func (r *DynamicRegistryImpl) InformerFor(gvr schema.GroupVersionResource) (cache.SharedIndexInformer, error) {
+	r.mu.Lock()
+	informer, ok := r.informers[gvr]
+	r.mu.Unlock()
+	if ok {
+		return informer, nil
+	}
+
+	defer runtime.HandleCrash()
+	informer = r.factory.ForResource(gvr).Informer()
+	r.factory.Start(r.stopCh)
+	if !cache.WaitForCacheSync(r.stopCh, informer.HasSynced) {
+		runtime.HandleError(fmt.Errorf("timed out waiting for caches to sync"))
+		return nil, fmt.Errorf("timed out waiting for caches to sync")
+	}
+
+	r.mu.Lock()
+	r.informers[gvr] = informer
+	r.mu.Unlock()
+	return informer, nil
+}
@@ -0,0 +1,43 @@
+package kubernetes
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+// Default index names registered by NewInformerRegistry. Handlers in
+// pkg/container/resource can look objects up in O(1)/O(k) via these
+// instead of iterating the full informer cache.
+const (
+	IndexPodByNodeName            = "spec.nodeName"
+	IndexPodByOwnerUID            = "ownerUID"
+	IndexEventByInvolvedObjectUID = "involvedObject.uid"
+)
+
+func indexPodByNodeName(obj interface{}) ([]string, error) {
+	pod, ok := obj.(*corev1.Pod)
+	if !ok || pod.Spec.NodeName == "" {
+		return []string{}, nil
+	}
+	return []string{pod.Spec.NodeName}, nil
+}
+
+func indexPodByOwnerUID(obj interface{}) ([]string, error) {
+	pod, ok := obj.(*corev1.Pod)
+	if !ok {
+		return []string{}, nil
+	}
+	uids := make([]string, 0, len(pod.OwnerReferences))
+	for _, owner := range pod.OwnerReferences {
+		uids = append(uids, string(owner.UID))
+	}
+	return uids, nil
+}
+
+func indexEventByInvolvedObjectUID(obj interface{}) ([]string, error) {
+	event, ok := obj.(*corev1.Event)
+	if !ok || event.InvolvedObject.UID == "" {
+		return []string{}, nil
+	}
+	return []string{string(event.InvolvedObject.UID)}, nil
+}
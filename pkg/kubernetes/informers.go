@@ -2,14 +2,43 @@ package kubernetes
 
 import (
 	"fmt"
+	"time"
+
+	"github.com/openspacee/ospagent/pkg/controller"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/informers"
 	appsv1 "k8s.io/client-go/informers/apps/v1"
 	"k8s.io/client-go/informers/core/v1"
 	"k8s.io/client-go/kubernetes"
+	appslisters "k8s.io/client-go/listers/apps/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
 )
 
+// InformerOptions scopes the shared informer factory so an agent can be
+// deployed with RBAC restricted to a single namespace, or watch only a
+// label-selected subset of objects, instead of the whole cluster.
+type InformerOptions struct {
+	// Namespace restricts every informer to a single namespace. Empty
+	// means cluster-wide, as before.
+	Namespace string
+	// TweakListOptions is applied to the ListOptions used by every
+	// informer's LIST and WATCH calls, e.g. to set a label selector.
+	TweakListOptions func(*metav1.ListOptions)
+	// ResyncPeriod is how often informers resync from their local cache.
+	// Zero disables periodic resync.
+	ResyncPeriod time.Duration
+	// Indexers adds custom indexers to a resource's informer, on top of
+	// the registry's own defaults (see indexers.go), keyed by resource
+	// name (e.g. "pod", "event"). These have to be supplied up front:
+	// NewInformerRegistry adds them before starting each informer, because
+	// SharedIndexInformer rejects AddIndexers once it has started, so
+	// there is no way to add an index after the registry has been
+	// constructed.
+	Indexers map[string]cache.Indexers
+}
+
 type InformerRegistry interface {
 	PodInformer() v1.PodInformer
 	NamespaceInformer() v1.NamespaceInformer
@@ -20,6 +49,20 @@ type InformerRegistry interface {
 	ConfigMapInformer() v1.ConfigMapInformer
 	StatefulSetInformer() appsv1.StatefulSetInformer
 	DaemonSetInformer() appsv1.DaemonSetInformer
+	// AddController builds a controller.Controller that reconciles the
+	// objects behind informer with handler, keyed by namespace/name. The
+	// caller is responsible for calling Run on the returned controller.
+	AddController(name string, informer cache.SharedIndexInformer, handler controller.SyncFunc) controller.Controller
+
+	PodLister() corelisters.PodLister
+	NamespaceLister() corelisters.NamespaceLister
+	NodeLister() corelisters.NodeLister
+	EventLister() corelisters.EventLister
+	DeploymentLister() appslisters.DeploymentLister
+	PersistentVolumeLister() corelisters.PersistentVolumeLister
+	ConfigMapLister() corelisters.ConfigMapLister
+	StatefulSetLister() appslisters.StatefulSetLister
+	DaemonSetLister() appslisters.DaemonSetLister
 }
 
 type InformerRegistryImpl struct {
@@ -34,47 +77,53 @@ type InformerRegistryImpl struct {
 	daemonSetInformer        appsv1.DaemonSetInformer
 }
 
-func NewInformerRegistry(kubeClient kubernetes.Interface, stopCh <-chan struct{}) (InformerRegistry, error) {
-	// 初始化 informer
-	factory := informers.NewSharedInformerFactory(kubeClient, 0)
+func NewInformerRegistry(kubeClient kubernetes.Interface, opts InformerOptions, stopCh <-chan struct{}) (InformerRegistry, error) {
+	// 初始化 informer，按命名空间/标签选择器过滤，避免在大集群或
+	// RBAC 受限部署下 watch 整个集群
+	factory := informers.NewSharedInformerFactoryWithOptions(
+		kubeClient,
+		opts.ResyncPeriod,
+		informers.WithNamespace(opts.Namespace),
+		informers.WithTweakListOptions(opts.TweakListOptions),
+	)
 	defer runtime.HandleCrash()
-	podInformer, err := NewPodInformer(factory, stopCh)
+	podInformer, err := NewPodInformer(factory, opts.Indexers["pod"], stopCh)
 	if err != nil {
 		return nil, err
 	}
-	nsInformer, err := NewNamespaceInformer(factory, stopCh)
+	nsInformer, err := NewNamespaceInformer(factory, opts.Indexers["namespace"], stopCh)
 	if err != nil {
 		return nil, err
 	}
-	nodeInformer, err := NewNodeInformer(factory, stopCh)
+	nodeInformer, err := NewNodeInformer(factory, opts.Indexers["node"], stopCh)
 	if err != nil {
 		return nil, err
 	}
-	persistentVolumeInformer, err := NewPersistentVolumeInformer(factory, stopCh)
+	persistentVolumeInformer, err := NewPersistentVolumeInformer(factory, opts.Indexers["persistentVolume"], stopCh)
 	if err != nil {
 		return nil, err
 	}
-	configMapInformer, err := NewConfigMapInformer(factory, stopCh)
+	configMapInformer, err := NewConfigMapInformer(factory, opts.Indexers["configMap"], stopCh)
 	if err != nil {
 		return nil, err
 	}
 
-	eventInformer, err := NewEventInformer(factory, stopCh)
+	eventInformer, err := NewEventInformer(factory, opts.Indexers["event"], stopCh)
 	if err != nil {
 		return nil, err
 	}
 
-	deploymentInformer, err := NewDeploymentInformer(factory, stopCh)
+	deploymentInformer, err := NewDeploymentInformer(factory, opts.Indexers["deployment"], stopCh)
 	if err != nil {
 		return nil, err
 	}
 
-	statefulSetInformer, err := NewStatefulSetInformer(factory, stopCh)
+	statefulSetInformer, err := NewStatefulSetInformer(factory, opts.Indexers["statefulSet"], stopCh)
 	if err != nil {
 		return nil, err
 	}
 
-	daemonSetInformer, err := NewDaemonSetInformer(factory, stopCh)
+	daemonSetInformer, err := NewDaemonSetInformer(factory, opts.Indexers["daemonSet"], stopCh)
 	if err != nil {
 		return nil, err
 	}
@@ -92,11 +141,37 @@ func NewInformerRegistry(kubeClient kubernetes.Interface, stopCh <-chan struct{}
 	}, nil
 }
 
-func NewPodInformer(factory informers.SharedInformerFactory, stopCh <-chan struct{}) (v1.PodInformer, error) {
+// withIndexers merges extra on top of defaults and, if the result is
+// non-empty, adds it to informer. Both maps may be nil.
+func withIndexers(informer cache.SharedIndexInformer, defaults, extra cache.Indexers) error {
+	merged := make(cache.Indexers, len(defaults)+len(extra))
+	for field, fn := range defaults {
+		merged[field] = fn
+	}
+	for field, fn := range extra {
+		merged[field] = fn
+	}
+	if len(merged) == 0 {
+		return nil
+	}
+	return informer.AddIndexers(merged)
+}
+
+func NewPodInformer(factory informers.SharedInformerFactory, extraIndexers cache.Indexers, stopCh <-chan struct{}) (v1.PodInformer, error) {
 	podInformer := factory.Core().V1().Pods()
 	informer := podInformer.Informer()
 	defer runtime.HandleCrash()
 
+	// Indexers must be added before the informer starts: SharedIndexInformer
+	// rejects AddIndexers once it has started, so this has to happen before
+	// factory.Start/WaitForCacheSync below.
+	if err := withIndexers(informer, cache.Indexers{
+		IndexPodByNodeName: indexPodByNodeName,
+		IndexPodByOwnerUID: indexPodByOwnerUID,
+	}, extraIndexers); err != nil {
+		return nil, err
+	}
+
 	// 启动 informer，list & watch
 	factory.Start(stopCh)
 	//从 apiserver 同步资源，即 list
@@ -107,11 +182,15 @@ func NewPodInformer(factory informers.SharedInformerFactory, stopCh <-chan struc
 	return podInformer, nil
 }
 
-func NewNamespaceInformer(factory informers.SharedInformerFactory, stopCh <-chan struct{}) (v1.NamespaceInformer, error) {
+func NewNamespaceInformer(factory informers.SharedInformerFactory, extraIndexers cache.Indexers, stopCh <-chan struct{}) (v1.NamespaceInformer, error) {
 	nsInformer := factory.Core().V1().Namespaces()
 	informer := nsInformer.Informer()
 	defer runtime.HandleCrash()
 
+	if err := withIndexers(informer, nil, extraIndexers); err != nil {
+		return nil, err
+	}
+
 	// 启动 informer，list & watch
 	factory.Start(stopCh)
 	//从 apiserver 同步资源，即 list
@@ -122,11 +201,15 @@ func NewNamespaceInformer(factory informers.SharedInformerFactory, stopCh <-chan
 	return nsInformer, nil
 }
 
-func NewNodeInformer(factory informers.SharedInformerFactory, stopCh <-chan struct{}) (v1.NodeInformer, error) {
+func NewNodeInformer(factory informers.SharedInformerFactory, extraIndexers cache.Indexers, stopCh <-chan struct{}) (v1.NodeInformer, error) {
 	nodeInformer := factory.Core().V1().Nodes()
 	informer := nodeInformer.Informer()
 	defer runtime.HandleCrash()
 
+	if err := withIndexers(informer, nil, extraIndexers); err != nil {
+		return nil, err
+	}
+
 	factory.Start(stopCh)
 	if !cache.WaitForCacheSync(stopCh, informer.HasSynced) {
 		runtime.HandleError(fmt.Errorf("time out waiting for caches to sync"))
@@ -135,11 +218,19 @@ func NewNodeInformer(factory informers.SharedInformerFactory, stopCh <-chan stru
 	return nodeInformer, nil
 }
 
-func NewEventInformer(factory informers.SharedInformerFactory, stopCh <-chan struct{}) (v1.EventInformer, error) {
+func NewEventInformer(factory informers.SharedInformerFactory, extraIndexers cache.Indexers, stopCh <-chan struct{}) (v1.EventInformer, error) {
 	eventInformer := factory.Core().V1().Events()
 	informer := eventInformer.Informer()
 	defer runtime.HandleCrash()
 
+	// See NewPodInformer: indexers have to be registered before the
+	// informer starts.
+	if err := withIndexers(informer, cache.Indexers{
+		IndexEventByInvolvedObjectUID: indexEventByInvolvedObjectUID,
+	}, extraIndexers); err != nil {
+		return nil, err
+	}
+
 	factory.Start(stopCh)
 	if !cache.WaitForCacheSync(stopCh, informer.HasSynced) {
 		runtime.HandleError(fmt.Errorf("time out waiting for caches to sync"))
@@ -148,11 +239,15 @@ func NewEventInformer(factory informers.SharedInformerFactory, stopCh <-chan str
 	return eventInformer, nil
 }
 
-func NewDeploymentInformer(factory informers.SharedInformerFactory, stopCh <-chan struct{}) (appsv1.DeploymentInformer, error) {
+func NewDeploymentInformer(factory informers.SharedInformerFactory, extraIndexers cache.Indexers, stopCh <-chan struct{}) (appsv1.DeploymentInformer, error) {
 	deploymentInformer := factory.Apps().V1().Deployments()
 	informer := deploymentInformer.Informer()
 	defer runtime.HandleCrash()
 
+	if err := withIndexers(informer, nil, extraIndexers); err != nil {
+		return nil, err
+	}
+
 	factory.Start(stopCh)
 	if !cache.WaitForCacheSync(stopCh, informer.HasSynced) {
 		runtime.HandleError(fmt.Errorf("time out waiting for caches to sync"))
@@ -161,11 +256,15 @@ func NewDeploymentInformer(factory informers.SharedInformerFactory, stopCh <-cha
 	return deploymentInformer, nil
 }
 
-func NewPersistentVolumeInformer(factory informers.SharedInformerFactory, stopCh <-chan struct{}) (v1.PersistentVolumeInformer, error) {
+func NewPersistentVolumeInformer(factory informers.SharedInformerFactory, extraIndexers cache.Indexers, stopCh <-chan struct{}) (v1.PersistentVolumeInformer, error) {
 	persistentVolumeInformer := factory.Core().V1().PersistentVolumes()
 	informer := persistentVolumeInformer.Informer()
 	defer runtime.HandleCrash()
 
+	if err := withIndexers(informer, nil, extraIndexers); err != nil {
+		return nil, err
+	}
+
 	factory.Start(stopCh)
 	if !cache.WaitForCacheSync(stopCh, informer.HasSynced) {
 		runtime.HandleError(fmt.Errorf("time out waiting for caches to sync"))
@@ -174,11 +273,15 @@ func NewPersistentVolumeInformer(factory informers.SharedInformerFactory, stopCh
 	return persistentVolumeInformer, nil
 }
 
-func NewConfigMapInformer(factory informers.SharedInformerFactory, stopCh <-chan struct{}) (v1.ConfigMapInformer, error) {
+func NewConfigMapInformer(factory informers.SharedInformerFactory, extraIndexers cache.Indexers, stopCh <-chan struct{}) (v1.ConfigMapInformer, error) {
 	configMapInformer := factory.Core().V1().ConfigMaps()
 	informer := configMapInformer.Informer()
 	defer runtime.HandleCrash()
 
+	if err := withIndexers(informer, nil, extraIndexers); err != nil {
+		return nil, err
+	}
+
 	factory.Start(stopCh)
 	if !cache.WaitForCacheSync(stopCh, informer.HasSynced) {
 		runtime.HandleError(fmt.Errorf("time out waiting for caches to sync"))
@@ -187,11 +290,15 @@ func NewConfigMapInformer(factory informers.SharedInformerFactory, stopCh <-chan
 	return configMapInformer, nil
 }
 
-func NewStatefulSetInformer(factory informers.SharedInformerFactory, stopCh <-chan struct{}) (appsv1.StatefulSetInformer, error) {
+func NewStatefulSetInformer(factory informers.SharedInformerFactory, extraIndexers cache.Indexers, stopCh <-chan struct{}) (appsv1.StatefulSetInformer, error) {
 	statefulSetInformer := factory.Apps().V1().StatefulSets()
 	informer := statefulSetInformer.Informer()
 	defer runtime.HandleCrash()
 
+	if err := withIndexers(informer, nil, extraIndexers); err != nil {
+		return nil, err
+	}
+
 	factory.Start(stopCh)
 	if !cache.WaitForCacheSync(stopCh, informer.HasSynced) {
 		runtime.HandleError(fmt.Errorf("time out waiting for caches to sync"))
@@ -200,11 +307,15 @@ func NewStatefulSetInformer(factory informers.SharedInformerFactory, stopCh <-ch
 	return statefulSetInformer, nil
 }
 
-func NewDaemonSetInformer(factory informers.SharedInformerFactory, stopCh <-chan struct{}) (appsv1.DaemonSetInformer, error) {
+func NewDaemonSetInformer(factory informers.SharedInformerFactory, extraIndexers cache.Indexers, stopCh <-chan struct{}) (appsv1.DaemonSetInformer, error) {
 	daemonSetInformer := factory.Apps().V1().DaemonSets()
 	informer := daemonSetInformer.Informer()
 	defer runtime.HandleCrash()
 
+	if err := withIndexers(informer, nil, extraIndexers); err != nil {
+		return nil, err
+	}
+
 	factory.Start(stopCh)
 	if !cache.WaitForCacheSync(stopCh, informer.HasSynced) {
 		runtime.HandleError(fmt.Errorf("time out waiting for caches to sync"))
@@ -248,3 +359,43 @@ func (r *InformerRegistryImpl) StatefulSetInformer() appsv1.StatefulSetInformer
 func (r *InformerRegistryImpl) DaemonSetInformer() appsv1.DaemonSetInformer {
 	return r.daemonSetInformer
 }
+
+func (r *InformerRegistryImpl) AddController(name string, informer cache.SharedIndexInformer, handler controller.SyncFunc) controller.Controller {
+	return controller.New(name, informer, handler)
+}
+
+func (r *InformerRegistryImpl) PodLister() corelisters.PodLister {
+	return r.podInformer.Lister()
+}
+
+func (r *InformerRegistryImpl) NamespaceLister() corelisters.NamespaceLister {
+	return r.nameSpaceInformer.Lister()
+}
+
+func (r *InformerRegistryImpl) NodeLister() corelisters.NodeLister {
+	return r.nodeInformer.Lister()
+}
+
+func (r *InformerRegistryImpl) EventLister() corelisters.EventLister {
+	return r.eventInformer.Lister()
+}
+
+func (r *InformerRegistryImpl) DeploymentLister() appslisters.DeploymentLister {
+	return r.deploymentInformer.Lister()
+}
+
+func (r *InformerRegistryImpl) PersistentVolumeLister() corelisters.PersistentVolumeLister {
+	return r.persistentVolumeInformer.Lister()
+}
+
+func (r *InformerRegistryImpl) ConfigMapLister() corelisters.ConfigMapLister {
+	return r.configMapInformer.Lister()
+}
+
+func (r *InformerRegistryImpl) StatefulSetLister() appslisters.StatefulSetLister {
+	return r.statefulSetInformer.Lister()
+}
+
+func (r *InformerRegistryImpl) DaemonSetLister() appslisters.DaemonSetLister {
+	return r.daemonSetInformer.Lister()
+}